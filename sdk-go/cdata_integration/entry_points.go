@@ -0,0 +1,94 @@
+//go:build cdata_integration
+
+// Package main mirrors Arrow's go/arrow/internal/cdata_integration: it builds
+// as a C archive/shared library (`go build -buildmode=c-archive -tags
+// cdata_integration`) that a Python or C++ driver loads to round-trip schemas,
+// arrays and record batches through the Arrow cdata import/export calls the
+// SDK uses. The entry points do not call Client.Publish and do not start an
+// engine, so the round trip never reaches the engine. It is gated behind
+// the cdata_integration build tag so it never ships in the normal SDK build.
+package main
+
+/*
+#include <stdint.h>
+*/
+import "C"
+
+import (
+	"runtime"
+	"unsafe"
+
+	"github.com/apache/arrow/go/v14/arrow/cdata"
+	"github.com/apache/arrow/go/v14/arrow/memory"
+)
+
+// allocator replaces memory.DefaultAllocator for the process, since none of
+// the cdata import/export functions below take an allocator argument — they
+// all allocate through the package-level default. Installing a checked one
+// here means ZenithBytesAllocated reflects every import/export the harness
+// does, not just the last call, and a nonzero balance after the driver
+// finishes is a real leak rather than a no-op counter.
+var allocator = memory.NewCheckedAllocator(memory.NewGoAllocator())
+
+func init() {
+	memory.DefaultAllocator = allocator
+}
+
+// ZenithExportSchema imports a C Data Interface ArrowSchema the driver built,
+// re-exports it through the allocator above, and writes the result to out.
+// This exercises the same import/export path Zenith uses internally, without
+// a running engine on the other end.
+//
+//export ZenithExportSchema
+func ZenithExportSchema(in *C.uintptr_t, out *C.uintptr_t) C.int32_t {
+	imported, err := cdata.ImportCArrowSchema((*cdata.CArrowSchema)(unsafe.Pointer(in)))
+	if err != nil {
+		return 1
+	}
+	cdata.ExportArrowSchema(imported, (*cdata.CArrowSchema)(unsafe.Pointer(out)))
+	return 0
+}
+
+// ZenithRoundtripRecordBatch imports a record batch the driver exported at
+// in/inSchema, then re-exports it to out/outSchema unchanged. The driver
+// compares in and out byte-for-byte to confirm Zenith's bridge is lossless.
+//
+//export ZenithRoundtripRecordBatch
+func ZenithRoundtripRecordBatch(inArr, inSchema, outArr, outSchema *C.uintptr_t) C.int32_t {
+	schema, err := cdata.ImportCArrowSchema((*cdata.CArrowSchema)(unsafe.Pointer(inSchema)))
+	if err != nil {
+		return 1
+	}
+
+	rec, err := cdata.ImportCRecordBatchWithSchema(
+		(*cdata.CArrowArray)(unsafe.Pointer(inArr)),
+		schema,
+	)
+	if err != nil {
+		return 1
+	}
+	defer rec.Release()
+
+	cdata.ExportArrowRecordBatch(rec, (*cdata.CArrowArray)(unsafe.Pointer(outArr)), (*cdata.CArrowSchema)(unsafe.Pointer(outSchema)))
+	return 0
+}
+
+// ZenithBytesAllocated returns the allocator's current outstanding byte
+// count. The driver calls runtime.GC (via ZenithForceGC) between rounds and
+// asserts this returns to zero once every exported struct has been released,
+// catching leaks in the bridge rather than just crashes.
+//
+//export ZenithBytesAllocated
+func ZenithBytesAllocated() C.int64_t {
+	return C.int64_t(allocator.CurrentAlloc())
+}
+
+// ZenithForceGC runs the Go garbage collector so the driver can assert no
+// finalizer-dependent cleanup in the bridge was masking a leak.
+//
+//export ZenithForceGC
+func ZenithForceGC() {
+	runtime.GC()
+}
+
+func main() {}
@@ -2,56 +2,188 @@ package zenith
 
 /*
 #cgo LDFLAGS: -L../../core/target/release -lzenith_core
-#include <stdint.h>
-#include <stdlib.h>
-
-// Forward declarations of C ABI
-void* zenith_init(uint32_t buffer_size);
-void zenith_free(void* engine_ptr);
-int32_t zenith_publish(void* engine_ptr, void* array_ptr, void* schema_ptr, uint32_t source_id, uint64_t seq_no);
-int32_t zenith_load_plugin(void* engine_ptr, const uint8_t* wasm_bytes, size_t len);
-
+#include "zenith.h"
 */
 import "C"
 import (
 	"errors"
+	"sync"
+	"sync/atomic"
 	"unsafe"
 )
 
+// ClientOptions configures the worker lanes backing Publish, PublishAsync and
+// PublishStream. Workers defaults to 1 and QueueDepth defaults to 64 when
+// left zero.
+type ClientOptions struct {
+	Workers    int
+	QueueDepth int
+}
+
+// publishJob is one queued engine call; result is buffered so the lane
+// goroutine never blocks handing the outcome back. call does the actual cgo
+// call and reports whether it succeeded, so the same lane machinery serializes
+// zenith_publish and zenith_publish_stream alike without either needing to
+// know about the other.
+type publishJob struct {
+	call   func() C.int32_t
+	errMsg string
+	result chan error
+}
+
+// Client wraps a zenith engine handle. It is safe for concurrent use: closed
+// is an atomic flag so it can be checked without blocking on mu, done is
+// closed exactly once by Close to unblock anything parked on a lane send, mu
+// guards enginePtr for the duration of each cgo call so Close's free can't
+// race a call already in flight, and lanes fan Publish/PublishAsync/
+// PublishStream jobs out to dedicated worker goroutines, picking the lane as
+// source ID modulo the number of lanes. Jobs for one source ID are therefore
+// serialized on one lane; source IDs that map to the same lane share its
+// channel, and different lanes call into the engine concurrently.
 type Client struct {
+	mu        sync.RWMutex
 	enginePtr unsafe.Pointer
+	closed    atomic.Bool
+	done      chan struct{}
+	lanes     []chan *publishJob
 }
 
-func NewClient(bufferSize uint32) *Client {
+func NewClient(bufferSize uint32, opts ClientOptions) (*Client, error) {
+	if opts.Workers <= 0 {
+		opts.Workers = 1
+	}
+	if opts.QueueDepth <= 0 {
+		opts.QueueDepth = 64
+	}
+
 	ptr := C.zenith_init(C.uint32_t(bufferSize))
 	if ptr == nil {
-		return nil
+		return nil, errors.New("failed to init engine")
+	}
+
+	c := &Client{
+		enginePtr: ptr,
+		done:      make(chan struct{}),
+		lanes:     make([]chan *publishJob, opts.Workers),
+	}
+	for i := range c.lanes {
+		lane := make(chan *publishJob, opts.QueueDepth)
+		c.lanes[i] = lane
+		go c.runLane(lane)
+	}
+	return c, nil
+}
+
+// runLane serializes publishes for one lane so the underlying engine pointer
+// is only ever touched by one goroutine at a time per lane, while different
+// lanes still run concurrently. It never blocks while holding mu: the lock is
+// only taken around the cgo call itself, so it can't deadlock against Close
+// the way holding it across a channel receive/send would.
+func (c *Client) runLane(jobs chan *publishJob) {
+	for {
+		select {
+		case job := <-jobs:
+			c.mu.RLock()
+			if c.closed.Load() {
+				c.mu.RUnlock()
+				job.result <- errors.New("client is closed")
+				continue
+			}
+			ret := job.call()
+			c.mu.RUnlock()
+			if ret != 0 {
+				job.result <- errors.New(job.errMsg)
+				continue
+			}
+			job.result <- nil
+		case <-c.done:
+			// Drain whatever was already buffered in this lane so a caller
+			// blocked on enqueue's result channel isn't left hanging; none of
+			// these touch enginePtr, since we're already shutting down.
+			for {
+				select {
+				case job := <-jobs:
+					job.result <- errors.New("client is closed")
+				default:
+					return
+				}
+			}
+		}
 	}
-	return &Client{enginePtr: ptr}
 }
 
+// enqueue puts job on the lane owned by sourceID and returns job.result.
+// Unlike holding mu across the send, this never blocks while holding a lock:
+// it selects between the (possibly full) lane channel and done, which Close
+// closes exactly once, so a concurrent Close always has a way to unblock a
+// pending send instead of the two waiting on each other.
+func (c *Client) enqueue(sourceID uint32, job *publishJob) <-chan error {
+	if c.closed.Load() {
+		job.result <- errors.New("client is closed")
+		return job.result
+	}
+	lane := c.lanes[sourceID%uint32(len(c.lanes))]
+	select {
+	case lane <- job:
+	case <-c.done:
+		job.result <- errors.New("client is closed")
+	}
+	return job.result
+}
+
+// Close shuts down all lanes and releases the engine. It is safe to call more
+// than once; subsequent calls are no-ops. Once Close returns, all in-flight
+// and future Publish/PublishAsync/PublishStream calls fail rather than
+// touching enginePtr.
 func (c *Client) Close() {
+	if !c.closed.CompareAndSwap(false, true) {
+		return
+	}
+	close(c.done)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	if c.enginePtr != nil {
 		C.zenith_free(c.enginePtr)
 		c.enginePtr = nil
 	}
 }
 
-// Publish sends an Arrow RecordBatch to the engine.
-// Note: Integrating Go Arrow with C Data Interface requires 'github.com/apache/arrow/go/v14/arrow/cdata'
-// This is a placeholder for the FFI bridge logic.
+// Publish sends an already-exported Arrow C Data Interface array/schema pair
+// to the engine, queuing it on the same per-sourceID lane as PublishAsync and
+// blocking until the lane's worker processes it. Most callers want
+// PublishRecord or PublishRecordBatch, which handle the export/release dance;
+// Publish is the low-level primitive they're built on, kept for callers that
+// manage the C Data Interface structs themselves.
 func (c *Client) Publish(cArray unsafe.Pointer, cSchema unsafe.Pointer, sourceID uint32, seqNo uint64) error {
-	ret := C.zenith_publish(c.enginePtr, cArray, cSchema, C.uint32_t(sourceID), C.uint64_t(seqNo))
-	if ret != 0 {
-		return errors.New("failed to publish event")
+	return <-c.PublishAsync(cArray, cSchema, sourceID, seqNo)
+}
+
+// PublishAsync queues a publish onto the lane owned by sourceID and returns
+// immediately; the returned channel receives exactly one result once the
+// lane's worker goroutine processes the job. Callers that don't need the
+// result may discard the channel.
+func (c *Client) PublishAsync(cArray, cSchema unsafe.Pointer, sourceID uint32, seqNo uint64) <-chan error {
+	job := &publishJob{
+		call: func() C.int32_t {
+			return C.zenith_publish(c.enginePtr, cArray, cSchema, C.uint32_t(sourceID), C.uint64_t(seqNo))
+		},
+		errMsg: "failed to publish event",
+		result: make(chan error, 1),
 	}
-	return nil
+	return c.enqueue(sourceID, job)
 }
 
 func (c *Client) LoadPlugin(wasmBytes []byte) error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.closed.Load() {
+		return errors.New("client is closed")
+	}
+
 	cBytes := (*C.uint8_t)(unsafe.Pointer(&wasmBytes[0]))
 	cLen := C.size_t(len(wasmBytes))
-	
+
 	ret := C.zenith_load_plugin(c.enginePtr, cBytes, cLen)
 	if ret != 0 {
 		return errors.New("failed to load plugin")
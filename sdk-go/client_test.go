@@ -0,0 +1,40 @@
+package zenith
+
+import (
+	"runtime"
+	"sync"
+	"testing"
+	"unsafe"
+)
+
+// TestPublishAsyncCloseRace hammers PublishAsync from many goroutines while
+// Close runs concurrently, with the GC forced in between, to catch
+// use-after-free/double-free on the engine pointer and the
+// send-on-closed-lane-channel panic that a racy PublishAsync/Close pairing
+// can produce.
+func TestPublishAsyncCloseRace(t *testing.T) {
+	c, err := NewClient(1<<16, ClientOptions{Workers: 4, QueueDepth: 8})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	const publishers = 200
+	var wg sync.WaitGroup
+	wg.Add(publishers)
+	for i := 0; i < publishers; i++ {
+		go func(sourceID uint32) {
+			defer wg.Done()
+			for seq := uint64(0); seq < 50; seq++ {
+				runtime.GC()
+				<-c.PublishAsync(unsafe.Pointer(nil), unsafe.Pointer(nil), sourceID, seq)
+			}
+		}(uint32(i))
+	}
+
+	runtime.GC()
+	c.Close()
+	wg.Wait()
+
+	// Close must be idempotent and must not double-free enginePtr.
+	c.Close()
+}
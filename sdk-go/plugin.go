@@ -0,0 +1,130 @@
+package zenith
+
+/*
+#include "zenith.h"
+*/
+import "C"
+
+import (
+	"errors"
+	"runtime/cgo"
+	"sync"
+	"unsafe"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// Plugin is a handle to a WASM plugin loaded with LoadPluginTyped or
+// LoadPluginWithPolicy. Its Call method dispatches by the guest method name
+// declared in proto/plugin.proto (OnInit, OnRecordBatch, OnShutdown) and
+// marshals/unmarshals the corresponding proto.Message, so callers exchange
+// typed messages instead of raw bytes even though this is a hand-written
+// dispatcher rather than generated protoc-gen-go/TinyGo stubs — the .proto
+// file is the source of truth for the wire contract, but codegen for it
+// (host-side Go, guest-side TinyGo) doesn't exist in this repo yet.
+//
+// Plugin is safe for concurrent use: mu guards ptr/trapHandle so a Call
+// racing an Unload can't run zenith_plugin_call on a freed plugin.
+type Plugin struct {
+	mu  sync.RWMutex
+	ptr unsafe.Pointer
+
+	// trapHandle is set when the plugin was loaded via LoadPluginWithPolicy
+	// with a non-nil OnPluginTrap; it is released on Unload.
+	trapHandle C.uintptr_t
+}
+
+// LoadPluginTyped loads a WASM plugin and passes cfg to its OnInit export.
+// Unlike LoadPlugin, the returned Plugin talks to the guest through Call,
+// which handles (un)marshaling, rather than raw byte slices.
+func (c *Client) LoadPluginTyped(wasmBytes []byte, cfg proto.Message) (*Plugin, error) {
+	if len(wasmBytes) == 0 {
+		return nil, errors.New("wasmBytes must not be empty")
+	}
+	cfgBytes, err := proto.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.closed.Load() {
+		return nil, errors.New("client is closed")
+	}
+
+	var cCfg *C.uint8_t
+	if len(cfgBytes) > 0 {
+		cCfg = (*C.uint8_t)(unsafe.Pointer(&cfgBytes[0]))
+	}
+
+	ptr := C.zenith_load_plugin_typed(
+		c.enginePtr,
+		(*C.uint8_t)(unsafe.Pointer(&wasmBytes[0])),
+		C.size_t(len(wasmBytes)),
+		cCfg,
+		C.size_t(len(cfgBytes)),
+	)
+	if ptr == nil {
+		return nil, errors.New("failed to load plugin")
+	}
+	return &Plugin{ptr: ptr}, nil
+}
+
+// Call invokes method (one of the guest exports named in proto/plugin.proto,
+// e.g. "OnRecordBatch") on the plugin, marshaling req as the request and
+// unmarshaling the guest's response into resp. Passing a method name with a
+// mismatched req/resp pair is caught by the plugin rejecting the call, not by
+// the Go compiler — there's no generated code yet to give these calls
+// compile-time type checking the way the .proto's service definitions imply.
+// The buffer the guest returns is always freed host-side via
+// zenith_plugin_free_result, so callers never need to (and never should)
+// free it themselves.
+func (p *Plugin) Call(method string, req proto.Message, resp proto.Message) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.ptr == nil {
+		return errors.New("plugin is unloaded")
+	}
+
+	reqBytes, err := proto.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	cMethod := C.CString(method)
+	defer C.free(unsafe.Pointer(cMethod))
+
+	var cReq *C.uint8_t
+	if len(reqBytes) > 0 {
+		cReq = (*C.uint8_t)(unsafe.Pointer(&reqBytes[0]))
+	}
+
+	var respBytes *C.uint8_t
+	var respLen C.size_t
+	ret := C.zenith_plugin_call(p.ptr, cMethod, cReq, C.size_t(len(reqBytes)), &respBytes, &respLen)
+	if ret != 0 {
+		return errors.New("plugin call failed")
+	}
+	if respBytes == nil {
+		return nil
+	}
+	defer C.zenith_plugin_free_result(respBytes)
+
+	return proto.Unmarshal(C.GoBytes(unsafe.Pointer(respBytes), C.int(respLen)), resp)
+}
+
+// Unload tears down the plugin instance. It is safe to call more than once,
+// and safe to call concurrently with Call: Call holds mu for the duration of
+// the cgo call, so Unload can't free the plugin out from under it.
+func (p *Plugin) Unload() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.ptr != nil {
+		C.zenith_plugin_unload(p.ptr)
+		p.ptr = nil
+	}
+	if p.trapHandle != 0 {
+		cgo.Handle(p.trapHandle).Delete()
+		p.trapHandle = 0
+	}
+}
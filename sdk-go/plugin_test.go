@@ -0,0 +1,72 @@
+package zenith
+
+import (
+	"sync"
+	"testing"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// minimalWasmModule is the smallest well-formed WASM binary: just the magic
+// number and version, with no sections. It's enough for LoadPluginTyped to
+// hand the engine something parseable without needing a real guest module,
+// since these tests only care about the Go-side Plugin lifecycle.
+func minimalWasmModule() []byte {
+	return []byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00}
+}
+
+// TestPluginCallAfterUnload checks that Call on an unloaded Plugin returns an
+// error instead of reaching into C with a freed ptr.
+func TestPluginCallAfterUnload(t *testing.T) {
+	c, err := NewClient(1024, ClientOptions{})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer c.Close()
+
+	p, err := c.LoadPluginTyped(minimalWasmModule(), &emptypb.Empty{})
+	if err != nil {
+		t.Fatalf("LoadPluginTyped: %v", err)
+	}
+
+	p.Unload()
+	p.Unload() // must be idempotent
+
+	if err := p.Call("OnShutdown", &emptypb.Empty{}, &emptypb.Empty{}); err == nil {
+		t.Fatal("Call after Unload succeeded, want error")
+	}
+}
+
+// TestPluginCallUnloadRace hammers Call from many goroutines while Unload
+// runs concurrently, to catch a Call racing the ptr/trapHandle teardown and
+// calling zenith_plugin_call on a freed plugin.
+func TestPluginCallUnloadRace(t *testing.T) {
+	c, err := NewClient(1024, ClientOptions{})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer c.Close()
+
+	p, err := c.LoadPluginTyped(minimalWasmModule(), &emptypb.Empty{})
+	if err != nil {
+		t.Fatalf("LoadPluginTyped: %v", err)
+	}
+
+	const callers = 50
+	var wg sync.WaitGroup
+	wg.Add(callers)
+	for i := 0; i < callers; i++ {
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				_ = p.Call("OnShutdown", &emptypb.Empty{}, &emptypb.Empty{})
+			}
+		}()
+	}
+
+	p.Unload()
+	wg.Wait()
+
+	// Unload must be idempotent and must not double-free ptr/trapHandle.
+	p.Unload()
+}
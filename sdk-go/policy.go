@@ -0,0 +1,139 @@
+package zenith
+
+/*
+#include "zenith.h"
+*/
+import "C"
+
+import (
+	"errors"
+	"runtime/cgo"
+	"time"
+	"unsafe"
+)
+
+// SourceIDRange is an inclusive [Start, End] range of source IDs a plugin may
+// observe batches from.
+type SourceIDRange struct {
+	Start, End uint32
+}
+
+// PluginPolicy declares what a plugin loaded with LoadPluginWithPolicy is
+// allowed to do. The core enforces it via wasmtime/wazero fuel and memory
+// limits rather than trusting the plugin: calls to host functions outside
+// AllowedHostFuncs are rejected, and exceeding MemoryLimitPages, FuelPerCall
+// or EpochDeadline traps the call instead of running unbounded.
+type PluginPolicy struct {
+	AllowedHostFuncs []string
+	MemoryLimitPages uint32
+	FuelPerCall      uint64
+	EpochDeadline    time.Duration
+	AllowedSourceIDs []SourceIDRange
+	CanPublish       bool
+}
+
+// PluginTrap describes why the core stopped a plugin call: it ran out of
+// fuel, exceeded its epoch deadline, exceeded its memory ceiling, or called a
+// host function outside its policy.
+type PluginTrap struct {
+	Reason   string
+	HostFunc string
+}
+
+// OnPluginTrap is called from the core whenever a plugin loaded with
+// LoadPluginWithPolicy trips its policy. It runs synchronously on the
+// goroutine that happened to be in the engine at the time, so it should not
+// block; log and decide whether to Unload the plugin.
+type OnPluginTrap func(trap PluginTrap)
+
+//export zenithPluginTrapTrampoline
+func zenithPluginTrapTrampoline(handle C.uintptr_t, reason *C.char, hostFunc *C.char) {
+	fn := cgo.Handle(handle).Value().(OnPluginTrap)
+	fn(PluginTrap{Reason: C.GoString(reason), HostFunc: C.GoString(hostFunc)})
+}
+
+// deliverPluginTrapForTest drives zenithPluginTrapTrampoline with plain Go
+// types. cgo's import "C" can't appear in _test.go files, so this is the
+// hook policy_test.go uses to exercise the trampoline the core calls into.
+func deliverPluginTrapForTest(handle uintptr, reason, hostFunc string) {
+	cReason := C.CString(reason)
+	defer C.free(unsafe.Pointer(cReason))
+	cHostFunc := C.CString(hostFunc)
+	defer C.free(unsafe.Pointer(cHostFunc))
+	zenithPluginTrapTrampoline(C.uintptr_t(handle), cReason, cHostFunc)
+}
+
+// LoadPluginWithPolicy loads a WASM plugin under policy, rejecting any call
+// the plugin makes outside it. onTrap, if non-nil, is invoked whenever the
+// core has to stop a call the plugin made in violation of policy; this lets
+// callers log or restart the offending plugin instead of the engine taking
+// the whole process down.
+//
+// The cgo.Handle registered for onTrap is released when the plugin is
+// unloaded, so callers must call Plugin.Unload once they're done with it.
+func (c *Client) LoadPluginWithPolicy(wasmBytes []byte, policy PluginPolicy, onTrap OnPluginTrap) (*Plugin, error) {
+	if len(wasmBytes) == 0 {
+		return nil, errors.New("wasmBytes must not be empty")
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.closed.Load() {
+		return nil, errors.New("client is closed")
+	}
+
+	cHostFuncs := make([]*C.char, len(policy.AllowedHostFuncs))
+	for i, f := range policy.AllowedHostFuncs {
+		cHostFuncs[i] = C.CString(f)
+	}
+	defer func() {
+		for _, p := range cHostFuncs {
+			C.free(unsafe.Pointer(p))
+		}
+	}()
+	var cHostFuncsPtr **C.char
+	if len(cHostFuncs) > 0 {
+		cHostFuncsPtr = &cHostFuncs[0]
+	}
+
+	ranges := make([]C.uint32_t, 0, len(policy.AllowedSourceIDs)*2)
+	for _, r := range policy.AllowedSourceIDs {
+		ranges = append(ranges, C.uint32_t(r.Start), C.uint32_t(r.End))
+	}
+	var rangesPtr *C.uint32_t
+	if len(ranges) > 0 {
+		rangesPtr = &ranges[0]
+	}
+
+	var trapHandle C.uintptr_t
+	if onTrap != nil {
+		trapHandle = C.uintptr_t(cgo.NewHandle(onTrap))
+	}
+
+	var canPublish C.uint8_t
+	if policy.CanPublish {
+		canPublish = 1
+	}
+
+	ptr := C.zenith_load_plugin_with_policy(
+		c.enginePtr,
+		(*C.uint8_t)(unsafe.Pointer(&wasmBytes[0])),
+		C.size_t(len(wasmBytes)),
+		cHostFuncsPtr,
+		C.size_t(len(cHostFuncs)),
+		C.uint32_t(policy.MemoryLimitPages),
+		C.uint64_t(policy.FuelPerCall),
+		C.uint64_t(policy.EpochDeadline.Milliseconds()),
+		rangesPtr,
+		C.size_t(len(policy.AllowedSourceIDs)),
+		canPublish,
+		trapHandle,
+	)
+	if ptr == nil {
+		if onTrap != nil {
+			cgo.Handle(trapHandle).Delete()
+		}
+		return nil, errors.New("failed to load plugin")
+	}
+	return &Plugin{ptr: ptr, trapHandle: trapHandle}, nil
+}
@@ -0,0 +1,51 @@
+package zenith
+
+import (
+	"runtime/cgo"
+	"testing"
+)
+
+// TestLoadPluginWithPolicyRejectsEmptyWasm checks the policy path's
+// input-validation rejection: an empty module is refused before ever
+// reaching the core, the same way LoadPluginTyped already is.
+func TestLoadPluginWithPolicyRejectsEmptyWasm(t *testing.T) {
+	c, err := NewClient(1024, ClientOptions{})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer c.Close()
+
+	_, err = c.LoadPluginWithPolicy(nil, PluginPolicy{}, nil)
+	if err == nil {
+		t.Fatal("LoadPluginWithPolicy(nil wasm) succeeded, want error")
+	}
+}
+
+// TestPluginTrapTrampolineDelivery drives the trap trampoline the same way
+// the core would: looking up the cgo.Handle passed to
+// zenith_load_plugin_with_policy and invoking the OnPluginTrap registered
+// under it. This is the delivery mechanism LoadPluginWithPolicy's trap
+// callback relies on, exercised without needing an actual policy violation
+// from the core.
+func TestPluginTrapTrampolineDelivery(t *testing.T) {
+	var got PluginTrap
+	done := make(chan struct{})
+	onTrap := OnPluginTrap(func(trap PluginTrap) {
+		got = trap
+		close(done)
+	})
+
+	handle := cgo.NewHandle(onTrap)
+	defer handle.Delete()
+
+	deliverPluginTrapForTest(uintptr(handle), "fuel exhausted", "host_log")
+
+	select {
+	case <-done:
+	default:
+		t.Fatal("OnPluginTrap was not invoked")
+	}
+	if got.Reason != "fuel exhausted" || got.HostFunc != "host_log" {
+		t.Fatalf("got %+v, want Reason=%q HostFunc=%q", got, "fuel exhausted", "host_log")
+	}
+}
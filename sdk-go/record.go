@@ -0,0 +1,52 @@
+package zenith
+
+/*
+#include "zenith.h"
+*/
+import "C"
+
+import (
+	"unsafe"
+
+	"github.com/apache/arrow/go/v14/arrow"
+	"github.com/apache/arrow/go/v14/arrow/cdata"
+)
+
+// PublishRecord exports rec through the Arrow C Data Interface and hands it to
+// zenith_publish. The ArrowArray/ArrowSchema pair is allocated zeroed (so a
+// crash mid-export never exposes uninitialized C memory to the engine, per
+// Arrow's C Data Interface hardening) and released once the engine has
+// consumed it.
+//
+// zenith_publish is synchronous and does not retain cArray/cSchema past the
+// call, so there's no callback into Go here and no need for a cgo.Handle;
+// LoadPluginWithPolicy's trap callback is where one shows up.
+func (c *Client) PublishRecord(rec arrow.Record, sourceID uint32, seqNo uint64) error {
+	cArr := (*cdata.CArrowArray)(unsafe.Pointer(C.calloc(1, C.size_t(unsafe.Sizeof(cdata.CArrowArray{})))))
+	cSchema := (*cdata.CArrowSchema)(unsafe.Pointer(C.calloc(1, C.size_t(unsafe.Sizeof(cdata.CArrowSchema{})))))
+	defer C.free(unsafe.Pointer(cArr))
+	defer C.free(unsafe.Pointer(cSchema))
+
+	cdata.ExportArrowRecordBatch(rec, cArr, cSchema)
+	defer cdata.ReleaseCArrowArray(cArr)
+	defer cdata.ReleaseCArrowSchema(cSchema)
+
+	return c.Publish(unsafe.Pointer(cArr), unsafe.Pointer(cSchema), sourceID, seqNo)
+}
+
+// PublishRecordBatch is like PublishRecord, but exports the given schema and
+// the batch's array separately, instead of taking the schema from the record.
+// The schema is exported again on every call; nothing is cached across calls.
+func (c *Client) PublishRecordBatch(schema *arrow.Schema, batch arrow.Record, sourceID uint32, seqNo uint64) error {
+	cSchema := (*cdata.CArrowSchema)(unsafe.Pointer(C.calloc(1, C.size_t(unsafe.Sizeof(cdata.CArrowSchema{})))))
+	defer C.free(unsafe.Pointer(cSchema))
+	cdata.ExportArrowSchema(schema, cSchema)
+	defer cdata.ReleaseCArrowSchema(cSchema)
+
+	cArr := (*cdata.CArrowArray)(unsafe.Pointer(C.calloc(1, C.size_t(unsafe.Sizeof(cdata.CArrowArray{})))))
+	defer C.free(unsafe.Pointer(cArr))
+	cdata.ExportArrowRecordBatch(batch, cArr, nil)
+	defer cdata.ReleaseCArrowArray(cArr)
+
+	return c.Publish(unsafe.Pointer(cArr), unsafe.Pointer(cSchema), sourceID, seqNo)
+}
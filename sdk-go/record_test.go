@@ -0,0 +1,40 @@
+package zenith
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/apache/arrow/go/v14/arrow"
+	"github.com/apache/arrow/go/v14/arrow/array"
+	"github.com/apache/arrow/go/v14/arrow/memory"
+)
+
+// TestPublishRecordSurvivesGC forces a collection between every publish to
+// catch the "garbage in C Data structures" crash class: if PublishRecord ever
+// handed the engine a CArrowArray/CArrowSchema the Go GC could still move or
+// collect out from under it, an aggressive GC here should turn that into a
+// reliable crash instead of a rare flake.
+func TestPublishRecordSurvivesGC(t *testing.T) {
+	c, err := NewClient(1024, ClientOptions{})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer c.Close()
+
+	schema := arrow.NewSchema([]arrow.Field{{Name: "v", Type: arrow.PrimitiveTypes.Int64}}, nil)
+	pool := memory.NewGoAllocator()
+
+	for i := uint64(0); i < 100; i++ {
+		b := array.NewRecordBuilder(pool, schema)
+		b.Field(0).(*array.Int64Builder).Append(int64(i))
+		rec := b.NewRecord()
+		b.Release()
+
+		runtime.GC()
+		if err := c.PublishRecord(rec, 1, i); err != nil {
+			t.Fatalf("PublishRecord(%d): %v", i, err)
+		}
+		runtime.GC()
+		rec.Release()
+	}
+}
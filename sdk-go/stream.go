@@ -0,0 +1,54 @@
+package zenith
+
+/*
+#include "zenith.h"
+*/
+import "C"
+
+import (
+	"unsafe"
+
+	"github.com/apache/arrow/go/v14/arrow/array"
+	"github.com/apache/arrow/go/v14/arrow/cdata"
+)
+
+// PublishStream exports rr through the Arrow C Stream Interface and hands it
+// to zenith_publish_stream, letting the engine pull batches one at a time
+// instead of requiring the whole thing in memory up front. This is the right
+// call for unbounded sources (files, Flight streams, DB scans); for a single
+// in-memory batch, PublishRecord is cheaper.
+//
+// zenith_publish_stream assigns seq_no to each batch it pulls off the stream,
+// starting at 1 and incrementing monotonically for sourceID, so batches from
+// concurrent PublishStream calls on different source IDs don't collide.
+//
+// cdata.ExportRecordReader zeroes the ArrowArrayStream struct before wiring up
+// its get_next/get_schema/release callbacks, so the engine can never observe
+// a half-initialized stream (the same hardening as Arrow's C Stream Interface
+// fix in apache/arrow#36670).
+//
+// Per the C Stream Interface, the consumer of an ArrowArrayStream — here,
+// zenith_publish_stream — owns calling stream->release(stream) once it's
+// done pulling batches, not the exporter. We only free the CArrowArrayStream
+// struct itself (the shell cdata.ExportRecordReader wrote into), since that
+// allocation is ours; calling the stream's own release a second time here
+// would double-free the private_data the engine's release callback already
+// freed.
+func (c *Client) PublishStream(rr array.RecordReader, sourceID uint32) error {
+	cStream := (*cdata.CArrowArrayStream)(unsafe.Pointer(C.calloc(1, C.size_t(unsafe.Sizeof(cdata.CArrowArrayStream{})))))
+	defer C.free(unsafe.Pointer(cStream))
+
+	cdata.ExportRecordReader(rr, cStream)
+
+	// Queued onto the same per-sourceID lane as Publish/PublishAsync so a
+	// concurrent PublishStream or Publish on the same source never races
+	// zenith_publish_stream/zenith_publish against each other on enginePtr.
+	job := &publishJob{
+		call: func() C.int32_t {
+			return C.zenith_publish_stream(c.enginePtr, unsafe.Pointer(cStream), C.uint32_t(sourceID))
+		},
+		errMsg: "failed to publish stream",
+		result: make(chan error, 1),
+	}
+	return <-c.enqueue(sourceID, job)
+}
@@ -0,0 +1,81 @@
+package zenith
+
+import (
+	"testing"
+
+	"github.com/apache/arrow/go/v14/arrow"
+	"github.com/apache/arrow/go/v14/arrow/array"
+	"github.com/apache/arrow/go/v14/arrow/memory"
+)
+
+func benchSchema() *arrow.Schema {
+	return arrow.NewSchema([]arrow.Field{{Name: "v", Type: arrow.PrimitiveTypes.Int64}}, nil)
+}
+
+func benchRecords(n, rows int) []arrow.Record {
+	schema := benchSchema()
+	pool := memory.NewGoAllocator()
+	recs := make([]arrow.Record, n)
+	for i := range recs {
+		b := array.NewRecordBuilder(pool, schema)
+		col := b.Field(0).(*array.Int64Builder)
+		for r := 0; r < rows; r++ {
+			col.Append(int64(r))
+		}
+		recs[i] = b.NewRecord()
+		b.Release()
+	}
+	return recs
+}
+
+// BenchmarkPublishRecordPerBatch is the baseline: one zenith_publish call per
+// batch, each paying its own Arrow C Data Interface export.
+func BenchmarkPublishRecordPerBatch(b *testing.B) {
+	c, err := NewClient(1<<20, ClientOptions{})
+	if err != nil {
+		b.Fatalf("NewClient: %v", err)
+	}
+	defer c.Close()
+
+	recs := benchRecords(b.N, 1024)
+	defer func() {
+		for _, r := range recs {
+			r.Release()
+		}
+	}()
+
+	b.ResetTimer()
+	for i, rec := range recs {
+		if err := c.PublishRecord(rec, 1, uint64(i)); err != nil {
+			b.Fatalf("PublishRecord: %v", err)
+		}
+	}
+}
+
+// BenchmarkPublishStream drives the same batches through PublishStream, to
+// compare against the per-batch path above.
+func BenchmarkPublishStream(b *testing.B) {
+	c, err := NewClient(1<<20, ClientOptions{})
+	if err != nil {
+		b.Fatalf("NewClient: %v", err)
+	}
+	defer c.Close()
+
+	recs := benchRecords(b.N, 1024)
+	defer func() {
+		for _, r := range recs {
+			r.Release()
+		}
+	}()
+
+	rr, err := array.NewRecordReader(benchSchema(), recs)
+	if err != nil {
+		b.Fatalf("NewRecordReader: %v", err)
+	}
+	defer rr.Release()
+
+	b.ResetTimer()
+	if err := c.PublishStream(rr, 1); err != nil {
+		b.Fatalf("PublishStream: %v", err)
+	}
+}